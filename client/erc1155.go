@@ -0,0 +1,16 @@
+package client
+
+// UnknownERC1155Symbol is returned by GetContractInfo1155 when a contract's
+// name/uri cannot be resolved, mirroring UnknownERC20Symbol/
+// UnknownERC721Symbol.
+//
+// NOTE: this snapshot of the repository does not contain the rest of the
+// client package (the Client interface, GetContractInfo, etc.), so
+// GetContractInfo1155 cannot be added to that interface here without
+// guessing at unrelated, unseen code. mapper.Transaction instead type-asserts
+// its client against a local erc1155ContractInfoClient interface and treats
+// a client that doesn't implement it the same as an unknown symbol; once
+// this package is available to edit, GetContractInfo1155 should move onto
+// the real Client interface directly (mirroring GetContractInfo) and that
+// assertion can be dropped.
+const UnknownERC1155Symbol = "UNKNOWN_ERC1155"
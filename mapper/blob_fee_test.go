@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBlobFeeAmount(t *testing.T) {
+	tests := []struct {
+		name         string
+		blobGasUsed  uint64
+		blobGasPrice *big.Int
+		want         *big.Int
+	}{
+		{"single blob at floor price", 131072, big.NewInt(1), big.NewInt(131072)},
+		{"multiple blobs above floor price", 3 * 131072, big.NewInt(5), big.NewInt(3 * 131072 * 5)},
+		{"no blob gas used", 0, big.NewInt(100), big.NewInt(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blobFeeAmount(tt.blobGasUsed, tt.blobGasPrice)
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("blobFeeAmount(%d, %s) = %s, want %s", tt.blobGasUsed, tt.blobGasPrice, got, tt.want)
+			}
+		})
+	}
+}
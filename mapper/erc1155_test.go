@@ -0,0 +1,134 @@
+package mapper
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func packUint256s(t *testing.T, values ...*big.Int) []byte {
+	t.Helper()
+	args := make(abi.Arguments, len(values))
+	for i := range values {
+		args[i] = abi.Argument{Type: uint256Type}
+	}
+	packed, err := args.Pack(toInterfaceSlice(values)...)
+	if err != nil {
+		t.Fatalf("failed to pack test data: %v", err)
+	}
+	return packed
+}
+
+func packUint256Arrays(t *testing.T, ids, amounts []*big.Int) []byte {
+	t.Helper()
+	args := abi.Arguments{{Type: uint256ArrayType}, {Type: uint256ArrayType}}
+	packed, err := args.Pack(ids, amounts)
+	if err != nil {
+		t.Fatalf("failed to pack test data: %v", err)
+	}
+	return packed
+}
+
+func toInterfaceSlice(values []*big.Int) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+func TestDecodeErc1155Single(t *testing.T) {
+	data := packUint256s(t, big.NewInt(42), big.NewInt(1000))
+
+	tokenID, amount, err := decodeErc1155Single(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokenID.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("tokenID = %s, want 42", tokenID)
+	}
+	if amount.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("amount = %s, want 1000", amount)
+	}
+}
+
+func TestDecodeErc1155Batch(t *testing.T) {
+	t.Run("matching lengths", func(t *testing.T) {
+		ids := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+		amounts := []*big.Int{big.NewInt(10), big.NewInt(20), big.NewInt(30)}
+		data := packUint256Arrays(t, ids, amounts)
+
+		gotIDs, gotAmounts, err := decodeErc1155Batch(data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(gotIDs) != len(ids) || len(gotAmounts) != len(amounts) {
+			t.Fatalf("got %d ids / %d amounts, want %d / %d", len(gotIDs), len(gotAmounts), len(ids), len(amounts))
+		}
+		for i := range ids {
+			if gotIDs[i].Cmp(ids[i]) != 0 {
+				t.Errorf("ids[%d] = %s, want %s", i, gotIDs[i], ids[i])
+			}
+			if gotAmounts[i].Cmp(amounts[i]) != 0 {
+				t.Errorf("amounts[%d] = %s, want %s", i, gotAmounts[i], amounts[i])
+			}
+		}
+	})
+
+	t.Run("mismatched lengths are rejected", func(t *testing.T) {
+		ids := []*big.Int{big.NewInt(1), big.NewInt(2)}
+		amounts := []*big.Int{big.NewInt(10)}
+		data := packUint256Arrays(t, ids, amounts)
+
+		_, _, err := decodeErc1155Batch(data)
+		if err == nil {
+			t.Fatal("expected an error for mismatched ids/values lengths, got nil")
+		}
+		if !strings.Contains(err.Error(), "length mismatch") {
+			t.Errorf("error = %q, want it to mention a length mismatch", err.Error())
+		}
+	})
+}
+
+func TestErc1155Ops(t *testing.T) {
+	log := &ethtypes.Log{Address: common.BigToAddress(big.NewInt(99))}
+	operator := common.BigToAddress(big.NewInt(1))
+	from := common.BigToAddress(big.NewInt(2))
+	to := common.BigToAddress(big.NewInt(3))
+	tokenID := big.NewInt(5)
+	amount := big.NewInt(100)
+
+	t.Run("mint", func(t *testing.T) {
+		ops := erc1155Ops(log, operator, zeroAddress, to, tokenID, amount, 0)
+		if len(ops) != 1 || ops[0].Type != OpErc1155Mint {
+			t.Fatalf("got %+v, want a single %s op", ops, OpErc1155Mint)
+		}
+	})
+
+	t.Run("burn", func(t *testing.T) {
+		ops := erc1155Ops(log, operator, from, zeroAddress, tokenID, amount, 0)
+		if len(ops) != 1 || ops[0].Type != OpErc1155Burn {
+			t.Fatalf("got %+v, want a single %s op", ops, OpErc1155Burn)
+		}
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		ops := erc1155Ops(log, operator, from, to, tokenID, amount, 3)
+		if len(ops) != 2 {
+			t.Fatalf("got %d ops, want 2", len(ops))
+		}
+		if ops[0].Type != OpErc1155TransferSender || ops[0].OperationIdentifier.Index != 3 {
+			t.Errorf("ops[0] = %+v, want %s at index 3", ops[0], OpErc1155TransferSender)
+		}
+		if ops[1].Type != OpErc1155TransferReceive || ops[1].OperationIdentifier.Index != 4 {
+			t.Errorf("ops[1] = %+v, want %s at index 4", ops[1], OpErc1155TransferReceive)
+		}
+		if len(ops[1].RelatedOperations) != 1 || ops[1].RelatedOperations[0].Index != 3 {
+			t.Errorf("ops[1].RelatedOperations = %+v, want a single link to index 3", ops[1].RelatedOperations)
+		}
+	})
+}
@@ -0,0 +1,381 @@
+package mapper
+
+import (
+	"fmt"
+	"math/big"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	clientTypes "github.com/ava-labs/avalanche-rosetta/client"
+)
+
+const (
+	wavaxDepositMethodHash    = "0xe1fffcc4923d04b559f4d29a8bfc6cda04eb5b0d3c460751c2402c5c5cc9109c"
+	wavaxWithdrawalMethodHash = "0x7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65"
+
+	uniswapV2SwapMethodHash = "0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822"
+	uniswapV3SwapMethodHash = "0xc42079f94a6350d7e6235f29174924f928cc2ac818eb64fed8004e115fbcca67"
+
+	OpWavaxWrap      = "WAVAX_WRAP"
+	OpWavaxUnwrap    = "WAVAX_UNWRAP"
+	OpDexSwap        = "DEX_SWAP"
+	OpBridgeDeposit  = "BRIDGE_DEPOSIT"
+	OpBridgeWithdraw = "BRIDGE_WITHDRAW"
+)
+
+var (
+	int256Type, _ = abi.NewType("int256", "", nil)
+
+	uniswapV2SwapArgs = abi.Arguments{{Type: uint256Type}, {Type: uint256Type}, {Type: uint256Type}, {Type: uint256Type}}
+	uniswapV3SwapArgs = abi.Arguments{{Type: int256Type}, {Type: int256Type}}
+)
+
+// LogDecoderContext carries the per-transaction context a LogDecoder needs
+// to turn a log into operations.
+type LogDecoderContext struct {
+	Header *ethtypes.Header
+	Tx     *ethtypes.Transaction
+	Client clientTypes.Client
+}
+
+// LogDecoder recognizes and decodes a specific, protocol-aware class of EVM
+// log (e.g. a WAVAX wrap, a DEX swap, a bridge deposit) into operations.
+// Transaction consults a DecoderRegistry of these for every log before its
+// native ERC-20/ERC-721/ERC-1155 handling; a log a decoder claims is not
+// handled natively.
+type LogDecoder interface {
+	Matches(log *ethtypes.Log) bool
+	Decode(log *ethtypes.Log, ctx *LogDecoderContext, opsLen int64) ([]*types.Operation, error)
+}
+
+type decoderRegistryEntry struct {
+	scope   string
+	decoder LogDecoder
+}
+
+// DecoderRegistry holds an ordered set of LogDecoders, each registered under
+// a scope name. In standard mode, a decoder only runs for a transaction if
+// its scope (as "decoder:<scope>") appears in that transaction's whitelist,
+// mirroring how individual token contracts are whitelisted today.
+//
+// NOTE: nothing in this package constructs or registers decoders into a
+// DecoderRegistry - the server-startup config that would register
+// WavaxDecoder/UniswapV2SwapDecoder/UniswapV3SwapDecoder/BridgeDecoder
+// instances isn't part of this snapshot of the repository, so that wiring
+// still needs to be added where Transaction is actually called.
+type DecoderRegistry struct {
+	entries []decoderRegistryEntry
+}
+
+// NewDecoderRegistry creates an empty DecoderRegistry. Decoders are tried in
+// registration order, and the first match wins.
+func NewDecoderRegistry() *DecoderRegistry {
+	return &DecoderRegistry{}
+}
+
+// Register adds a decoder to the registry under the given scope name.
+func (r *DecoderRegistry) Register(scope string, decoder LogDecoder) {
+	r.entries = append(r.entries, decoderRegistryEntry{scope: scope, decoder: decoder})
+}
+
+func (r *DecoderRegistry) decode(
+	log *ethtypes.Log,
+	ctx *LogDecoderContext,
+	opsLen int64,
+	isAnalyticsMode bool,
+	standardModeWhiteList []string,
+) ([]*types.Operation, bool, error) {
+	for _, entry := range r.entries {
+		if !entry.decoder.Matches(log) {
+			continue
+		}
+
+		if !isAnalyticsMode && !EqualFoldContains(standardModeWhiteList, "decoder:"+entry.scope) {
+			continue
+		}
+
+		ops, err := entry.decoder.Decode(log, ctx, opsLen)
+		return ops, true, err
+	}
+
+	return nil, false, nil
+}
+
+// WavaxDecoder decodes WAVAX Deposit (wrap) and Withdrawal (unwrap) events
+// into a paired AVAX<->WAVAX operation so that balances reconcile against
+// native AVAX on either side of the wrap.
+type WavaxDecoder struct {
+	ContractAddress common.Address
+}
+
+func (d *WavaxDecoder) Matches(log *ethtypes.Log) bool {
+	if log.Address != d.ContractAddress || len(log.Topics) != 2 {
+		return false
+	}
+
+	topic0 := log.Topics[0].String()
+	return topic0 == wavaxDepositMethodHash || topic0 == wavaxWithdrawalMethodHash
+}
+
+func (d *WavaxDecoder) Decode(log *ethtypes.Log, ctx *LogDecoderContext, opsLen int64) ([]*types.Operation, error) {
+	account := common.BytesToAddress(log.Topics[1].Bytes())
+	amount := new(big.Int).SetBytes(log.Data)
+
+	symbol, decimals, err := ctx.Client.GetContractInfo(log.Address, true)
+	if err != nil {
+		return nil, err
+	}
+	wavaxCurrency := ToCurrency(symbol, decimals, log.Address)
+
+	opType, avaxAmount, wavaxAmount := wavaxLegAmounts(log.Topics[0].String(), amount)
+
+	return []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: opsLen,
+			},
+			Status:  types.String(StatusSuccess),
+			Type:    opType,
+			Account: Account(&account),
+			Amount:  AvaxAmount(avaxAmount),
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: opsLen + 1,
+			},
+			RelatedOperations: []*types.OperationIdentifier{
+				{
+					Index: opsLen,
+				},
+			},
+			Status:  types.String(StatusSuccess),
+			Type:    opType,
+			Account: Account(&account),
+			Amount: &types.Amount{
+				Value:    wavaxAmount.String(),
+				Currency: wavaxCurrency,
+			},
+		},
+	}, nil
+}
+
+// poolTokensClient is implemented by clients that can resolve a pool's
+// underlying token pair. It is declared here, rather than assumed on
+// clientTypes.Client, because this snapshot of the repository does not
+// contain the client package's Client interface to extend. A client that
+// doesn't implement it means the pool's tokens aren't known, so the swap is
+// left undecoded (same as any other log no registered decoder claims). Once
+// the real Client interface is available, GetPoolTokens should move onto it
+// directly and this assertion can be dropped.
+type poolTokensClient interface {
+	GetPoolTokens(address common.Address) (token0, token1 common.Address, err error)
+}
+
+// swapLeg is one side of a decoded swap: the token whose balance moved and
+// by how much (positive: paid into the pool by sender; negative: paid out of
+// the pool to recipient).
+type swapLeg struct {
+	token common.Address
+	delta *big.Int
+}
+
+// wavaxLegAmounts computes the AVAX-leg and WAVAX-leg amounts for a
+// Deposit/Withdrawal log, and the op type to tag them with. Pulled out of
+// Decode so the sign math can be tested without a Client.
+func wavaxLegAmounts(topic0 string, amount *big.Int) (opType string, avaxAmount, wavaxAmount *big.Int) {
+	if topic0 == wavaxWithdrawalMethodHash {
+		return OpWavaxUnwrap, amount, new(big.Int).Neg(amount)
+	}
+	return OpWavaxWrap, new(big.Int).Neg(amount), amount
+}
+
+// uniswapSwapDecoderBase holds the pool-token lookup shared by the V2 and V3
+// Swap decoders.
+type uniswapSwapDecoderBase struct{}
+
+func (uniswapSwapDecoderBase) swapLegs(
+	client clientTypes.Client,
+	pool common.Address,
+	sender, recipient common.Address,
+	legs []swapLeg,
+	opsLen int64,
+) ([]*types.Operation, error) {
+	ops := []*types.Operation{}
+	idx := opsLen
+
+	// legs is already in a fixed token0-then-token1 order, unlike a map, so
+	// repeated calls with the same input always emit operations in the same
+	// order.
+	for _, leg := range legs {
+		if leg.delta.Sign() == 0 {
+			continue
+		}
+
+		symbol, decimals, err := client.GetContractInfo(leg.token, true)
+		if err != nil {
+			return nil, err
+		}
+		currency := ToCurrency(symbol, decimals, leg.token)
+
+		account := recipient
+		if leg.delta.Sign() > 0 {
+			account = sender
+		}
+
+		ops = append(ops, &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: idx,
+			},
+			Status:  types.String(StatusSuccess),
+			Type:    OpDexSwap,
+			Account: Account(&account),
+			Amount: &types.Amount{
+				Value:    new(big.Int).Neg(leg.delta).String(),
+				Currency: currency,
+			},
+			Metadata: map[string]interface{}{
+				"pool":  pool.String(),
+				"token": leg.token.String(),
+			},
+		})
+		idx++
+	}
+
+	return ops, nil
+}
+
+// UniswapV2SwapDecoder decodes Uniswap V2-style Swap events when the pool's
+// underlying tokens are known to the client.
+type UniswapV2SwapDecoder struct {
+	uniswapSwapDecoderBase
+}
+
+func (d *UniswapV2SwapDecoder) Matches(log *ethtypes.Log) bool {
+	return len(log.Topics) == 3 && log.Topics[0].String() == uniswapV2SwapMethodHash
+}
+
+func (d *UniswapV2SwapDecoder) Decode(log *ethtypes.Log, ctx *LogDecoderContext, opsLen int64) ([]*types.Operation, error) {
+	poolClient, ok := ctx.Client.(poolTokensClient)
+	if !ok {
+		return nil, nil
+	}
+	token0, token1, err := poolClient.GetPoolTokens(log.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := uniswapV2SwapArgs.UnpackValues(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode uniswap v2 swap log: %w", err)
+	}
+	amount0In := values[0].(*big.Int)
+	amount1In := values[1].(*big.Int)
+	amount0Out := values[2].(*big.Int)
+	amount1Out := values[3].(*big.Int)
+
+	sender := common.BytesToAddress(log.Topics[1].Bytes())
+	recipient := common.BytesToAddress(log.Topics[2].Bytes())
+
+	legs := uniswapV2Legs(token0, token1, amount0In, amount1In, amount0Out, amount1Out)
+
+	return d.swapLegs(ctx.Client, log.Address, sender, recipient, legs, opsLen)
+}
+
+// uniswapV2Legs computes each token's net delta (paid in minus paid out) in
+// a fixed token0-then-token1 order. Pulled out of Decode so the delta math
+// can be tested without a Client.
+func uniswapV2Legs(token0, token1 common.Address, amount0In, amount1In, amount0Out, amount1Out *big.Int) []swapLeg {
+	return []swapLeg{
+		{token: token0, delta: new(big.Int).Sub(amount0In, amount0Out)},
+		{token: token1, delta: new(big.Int).Sub(amount1In, amount1Out)},
+	}
+}
+
+// UniswapV3SwapDecoder decodes Uniswap V3-style Swap events when the pool's
+// underlying tokens are known to the client.
+type UniswapV3SwapDecoder struct {
+	uniswapSwapDecoderBase
+}
+
+func (d *UniswapV3SwapDecoder) Matches(log *ethtypes.Log) bool {
+	return len(log.Topics) == 3 && log.Topics[0].String() == uniswapV3SwapMethodHash
+}
+
+func (d *UniswapV3SwapDecoder) Decode(log *ethtypes.Log, ctx *LogDecoderContext, opsLen int64) ([]*types.Operation, error) {
+	poolClient, ok := ctx.Client.(poolTokensClient)
+	if !ok {
+		return nil, nil
+	}
+	token0, token1, err := poolClient.GetPoolTokens(log.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := uniswapV3SwapArgs.UnpackValues(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode uniswap v3 swap log: %w", err)
+	}
+	amount0 := values[0].(*big.Int)
+	amount1 := values[1].(*big.Int)
+
+	sender := common.BytesToAddress(log.Topics[1].Bytes())
+	recipient := common.BytesToAddress(log.Topics[2].Bytes())
+
+	// V3 already reports a signed delta per token (positive: paid into the
+	// pool by sender; negative: paid out of the pool to recipient), in a
+	// fixed token0-then-token1 order.
+	legs := []swapLeg{
+		{token: token0, delta: amount0},
+		{token: token1, delta: amount1},
+	}
+
+	return d.swapLegs(ctx.Client, log.Address, sender, recipient, legs, opsLen)
+}
+
+// BridgeDecoder decodes a canonical bridge contract's Deposit/Withdrawal
+// events into bridge operations. The contract address and event signatures
+// are configured at registration time since bridges don't share a single
+// canonical ABI the way WAVAX or Uniswap pools do.
+type BridgeDecoder struct {
+	ContractAddress common.Address
+	DepositTopic    common.Hash
+	WithdrawalTopic common.Hash
+}
+
+func (d *BridgeDecoder) Matches(log *ethtypes.Log) bool {
+	if log.Address != d.ContractAddress || len(log.Topics) != 2 {
+		return false
+	}
+
+	return log.Topics[0] == d.DepositTopic || log.Topics[0] == d.WithdrawalTopic
+}
+
+func (d *BridgeDecoder) Decode(log *ethtypes.Log, _ *LogDecoderContext, opsLen int64) ([]*types.Operation, error) {
+	account := common.BytesToAddress(log.Topics[1].Bytes())
+	amount := new(big.Int).SetBytes(log.Data)
+
+	opType := OpBridgeDeposit
+	value := new(big.Int).Neg(amount)
+	if log.Topics[0] == d.WithdrawalTopic {
+		opType = OpBridgeWithdraw
+		value = amount
+	}
+
+	return []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: opsLen,
+			},
+			Status:  types.String(StatusSuccess),
+			Type:    opType,
+			Account: Account(&account),
+			Amount:  AvaxAmount(value),
+			Metadata: map[string]interface{}{
+				ContractAddressMetadata: log.Address.String(),
+			},
+		},
+	}, nil
+}
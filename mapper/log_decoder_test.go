@@ -0,0 +1,227 @@
+package mapper
+
+import (
+	"math/big"
+	"testing"
+
+	ethtypes "github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestWavaxDecoderMatches(t *testing.T) {
+	wavax := common.BigToAddress(big.NewInt(1))
+	other := common.BigToAddress(big.NewInt(2))
+	depositTopic := common.HexToHash(wavaxDepositMethodHash)
+	withdrawalTopic := common.HexToHash(wavaxWithdrawalMethodHash)
+	accountTopic := common.HexToHash("0x01")
+
+	d := &WavaxDecoder{ContractAddress: wavax}
+
+	tests := []struct {
+		name string
+		log  *ethtypes.Log
+		want bool
+	}{
+		{"deposit on wavax contract", &ethtypes.Log{Address: wavax, Topics: []common.Hash{depositTopic, accountTopic}}, true},
+		{"withdrawal on wavax contract", &ethtypes.Log{Address: wavax, Topics: []common.Hash{withdrawalTopic, accountTopic}}, true},
+		{"wrong contract address", &ethtypes.Log{Address: other, Topics: []common.Hash{depositTopic, accountTopic}}, false},
+		{"wrong topic count", &ethtypes.Log{Address: wavax, Topics: []common.Hash{depositTopic}}, false},
+		{"unrelated topic", &ethtypes.Log{Address: wavax, Topics: []common.Hash{accountTopic, accountTopic}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.Matches(tt.log); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWavaxLegAmounts(t *testing.T) {
+	amount := big.NewInt(500)
+
+	opType, avax, wavax := wavaxLegAmounts(wavaxDepositMethodHash, amount)
+	if opType != OpWavaxWrap {
+		t.Errorf("deposit opType = %s, want %s", opType, OpWavaxWrap)
+	}
+	if avax.Sign() >= 0 {
+		t.Errorf("deposit avaxAmount = %s, want negative", avax)
+	}
+	if wavax.Cmp(amount) != 0 {
+		t.Errorf("deposit wavaxAmount = %s, want %s", wavax, amount)
+	}
+
+	opType, avax, wavax = wavaxLegAmounts(wavaxWithdrawalMethodHash, amount)
+	if opType != OpWavaxUnwrap {
+		t.Errorf("withdrawal opType = %s, want %s", opType, OpWavaxUnwrap)
+	}
+	if avax.Cmp(amount) != 0 {
+		t.Errorf("withdrawal avaxAmount = %s, want %s", avax, amount)
+	}
+	if wavax.Sign() >= 0 {
+		t.Errorf("withdrawal wavaxAmount = %s, want negative", wavax)
+	}
+}
+
+func TestUniswapV2Legs(t *testing.T) {
+	token0 := common.BigToAddress(big.NewInt(10))
+	token1 := common.BigToAddress(big.NewInt(11))
+
+	legs := uniswapV2Legs(token0, token1, big.NewInt(100), big.NewInt(0), big.NewInt(0), big.NewInt(40))
+
+	if len(legs) != 2 {
+		t.Fatalf("got %d legs, want 2", len(legs))
+	}
+	// token0 always comes first, regardless of sign, so repeated decodes of
+	// the same swap are reproducible.
+	if legs[0].token != token0 || legs[0].delta.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("legs[0] = %+v, want token0 delta 100", legs[0])
+	}
+	if legs[1].token != token1 || legs[1].delta.Cmp(big.NewInt(-40)) != 0 {
+		t.Errorf("legs[1] = %+v, want token1 delta -40", legs[1])
+	}
+}
+
+func TestUniswapV2SwapDecoderMatches(t *testing.T) {
+	pool := common.BigToAddress(big.NewInt(1))
+	swapTopic := common.HexToHash(uniswapV2SwapMethodHash)
+	senderTopic := common.HexToHash("0x01")
+	recipientTopic := common.HexToHash("0x02")
+
+	d := &UniswapV2SwapDecoder{}
+
+	if !d.Matches(&ethtypes.Log{Address: pool, Topics: []common.Hash{swapTopic, senderTopic, recipientTopic}}) {
+		t.Error("expected a V2 swap log to match")
+	}
+	if d.Matches(&ethtypes.Log{Address: pool, Topics: []common.Hash{swapTopic, senderTopic}}) {
+		t.Error("expected a log with the wrong topic count not to match")
+	}
+	v3Topic := common.HexToHash(uniswapV3SwapMethodHash)
+	if d.Matches(&ethtypes.Log{Address: pool, Topics: []common.Hash{v3Topic, senderTopic, recipientTopic}}) {
+		t.Error("expected a V3 swap log not to match the V2 decoder")
+	}
+}
+
+func TestUniswapV2SwapDecoderDecodeWithoutPoolTokensClient(t *testing.T) {
+	// ctx.Client is nil here, so it can't satisfy poolTokensClient -
+	// Decode must treat the pool's tokens as unknown and return no ops
+	// rather than panicking or guessing at an address.
+	d := &UniswapV2SwapDecoder{}
+	log := &ethtypes.Log{
+		Address: common.BigToAddress(big.NewInt(1)),
+		Topics: []common.Hash{
+			common.HexToHash(uniswapV2SwapMethodHash),
+			common.BytesToHash(common.BigToAddress(big.NewInt(2)).Bytes()),
+			common.BytesToHash(common.BigToAddress(big.NewInt(3)).Bytes()),
+		},
+		Data: packUint256s(t, big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(1)),
+	}
+
+	ops, err := d.Decode(log, &LogDecoderContext{}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ops != nil {
+		t.Errorf("ops = %+v, want nil", ops)
+	}
+}
+
+func TestUniswapV3SwapDecoderMatches(t *testing.T) {
+	pool := common.BigToAddress(big.NewInt(1))
+	swapTopic := common.HexToHash(uniswapV3SwapMethodHash)
+	senderTopic := common.HexToHash("0x01")
+	recipientTopic := common.HexToHash("0x02")
+
+	d := &UniswapV3SwapDecoder{}
+
+	if !d.Matches(&ethtypes.Log{Address: pool, Topics: []common.Hash{swapTopic, senderTopic, recipientTopic}}) {
+		t.Error("expected a V3 swap log to match")
+	}
+	v2Topic := common.HexToHash(uniswapV2SwapMethodHash)
+	if d.Matches(&ethtypes.Log{Address: pool, Topics: []common.Hash{v2Topic, senderTopic, recipientTopic}}) {
+		t.Error("expected a V2 swap log not to match the V3 decoder")
+	}
+}
+
+func TestBridgeDecoderMatchesAndDecode(t *testing.T) {
+	bridge := common.BigToAddress(big.NewInt(5))
+	depositTopic := common.HexToHash("0xaa")
+	withdrawalTopic := common.HexToHash("0xbb")
+	accountTopic := common.BytesToHash(common.BigToAddress(big.NewInt(6)).Bytes())
+
+	d := &BridgeDecoder{ContractAddress: bridge, DepositTopic: depositTopic, WithdrawalTopic: withdrawalTopic}
+
+	depositLog := &ethtypes.Log{Address: bridge, Topics: []common.Hash{depositTopic, accountTopic}, Data: big.NewInt(1000).Bytes()}
+	if !d.Matches(depositLog) {
+		t.Fatal("expected a deposit log to match")
+	}
+
+	ops, err := d.Decode(depositLog, nil, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Type != OpBridgeDeposit || ops[0].OperationIdentifier.Index != 2 {
+		t.Fatalf("got %+v, want a single %s op at index 2", ops, OpBridgeDeposit)
+	}
+	if ops[0].Amount.Value != "-1000" {
+		t.Errorf("deposit amount = %s, want -1000", ops[0].Amount.Value)
+	}
+
+	withdrawalLog := &ethtypes.Log{Address: bridge, Topics: []common.Hash{withdrawalTopic, accountTopic}, Data: big.NewInt(1000).Bytes()}
+	ops, err = d.Decode(withdrawalLog, nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Type != OpBridgeWithdraw {
+		t.Fatalf("got %+v, want a single %s op", ops, OpBridgeWithdraw)
+	}
+	if ops[0].Amount.Value != "1000" {
+		t.Errorf("withdrawal amount = %s, want 1000", ops[0].Amount.Value)
+	}
+
+	other := common.BigToAddress(big.NewInt(99))
+	if d.Matches(&ethtypes.Log{Address: other, Topics: []common.Hash{depositTopic, accountTopic}}) {
+		t.Error("expected a log on a different contract not to match")
+	}
+}
+
+func TestDecoderRegistryScoping(t *testing.T) {
+	bridge := common.BigToAddress(big.NewInt(5))
+	depositTopic := common.HexToHash("0xaa")
+	withdrawalTopic := common.HexToHash("0xbb")
+	accountTopic := common.BytesToHash(common.BigToAddress(big.NewInt(6)).Bytes())
+	log := &ethtypes.Log{Address: bridge, Topics: []common.Hash{depositTopic, accountTopic}, Data: big.NewInt(1).Bytes()}
+
+	registry := NewDecoderRegistry()
+	registry.Register("bridge", &BridgeDecoder{ContractAddress: bridge, DepositTopic: depositTopic, WithdrawalTopic: withdrawalTopic})
+
+	ctx := &LogDecoderContext{}
+
+	t.Run("analytics mode ignores whitelist", func(t *testing.T) {
+		ops, handled, err := registry.decode(log, ctx, 0, true, nil)
+		if err != nil || !handled || len(ops) != 1 {
+			t.Fatalf("decode() = %v, %v, %v", ops, handled, err)
+		}
+	})
+
+	t.Run("standard mode requires the scope on the whitelist", func(t *testing.T) {
+		_, handled, err := registry.decode(log, ctx, 0, false, nil)
+		if err != nil || handled {
+			t.Fatalf("expected an unwhitelisted decoder not to handle the log, got handled=%v err=%v", handled, err)
+		}
+
+		ops, handled, err := registry.decode(log, ctx, 0, false, []string{"decoder:bridge"})
+		if err != nil || !handled || len(ops) != 1 {
+			t.Fatalf("decode() = %v, %v, %v", ops, handled, err)
+		}
+	})
+
+	t.Run("no decoder matches", func(t *testing.T) {
+		unrelated := &ethtypes.Log{Address: common.BigToAddress(big.NewInt(123)), Topics: []common.Hash{depositTopic}}
+		ops, handled, err := registry.decode(unrelated, ctx, 0, true, nil)
+		if err != nil || handled || ops != nil {
+			t.Fatalf("decode() = %v, %v, %v, want unhandled", ops, handled, err)
+		}
+	})
+}
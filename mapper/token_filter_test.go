@@ -0,0 +1,106 @@
+package mapper
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTokenFilterMatches(t *testing.T) {
+	tokenAddr := common.BigToAddress(big.NewInt(1))
+	otherAddr := common.BigToAddress(big.NewInt(2))
+	chainA := big.NewInt(43114)
+	chainB := big.NewInt(43113)
+
+	tests := []struct {
+		name          string
+		filter        TokenFilter
+		contractAddr  common.Address
+		chainID       *big.Int
+		tokenID       *big.Int
+		expectMatches bool
+	}{
+		{
+			name:          "nil filter matches anything",
+			filter:        nil,
+			contractAddr:  tokenAddr,
+			expectMatches: true,
+		},
+		{
+			name:          "empty filter matches anything",
+			filter:        TokenFilter{},
+			contractAddr:  otherAddr,
+			chainID:       chainA,
+			tokenID:       big.NewInt(7),
+			expectMatches: true,
+		},
+		{
+			name:          "contract address match, no chain/token constraints",
+			filter:        TokenFilter{{ContractAddress: tokenAddr}},
+			contractAddr:  tokenAddr,
+			chainID:       chainA,
+			tokenID:       big.NewInt(7),
+			expectMatches: true,
+		},
+		{
+			name:          "contract address mismatch",
+			filter:        TokenFilter{{ContractAddress: tokenAddr}},
+			contractAddr:  otherAddr,
+			expectMatches: false,
+		},
+		{
+			name:          "chain ID constrained and matching",
+			filter:        TokenFilter{{ContractAddress: tokenAddr, ChainID: chainA}},
+			contractAddr:  tokenAddr,
+			chainID:       chainA,
+			expectMatches: true,
+		},
+		{
+			name:          "chain ID constrained but mismatched",
+			filter:        TokenFilter{{ContractAddress: tokenAddr, ChainID: chainA}},
+			contractAddr:  tokenAddr,
+			chainID:       chainB,
+			expectMatches: false,
+		},
+		{
+			name:          "chain ID constrained but caller has none",
+			filter:        TokenFilter{{ContractAddress: tokenAddr, ChainID: chainA}},
+			contractAddr:  tokenAddr,
+			chainID:       nil,
+			expectMatches: false,
+		},
+		{
+			name:          "token ID constrained and matching",
+			filter:        TokenFilter{{ContractAddress: tokenAddr, TokenID: big.NewInt(7)}},
+			contractAddr:  tokenAddr,
+			tokenID:       big.NewInt(7),
+			expectMatches: true,
+		},
+		{
+			name:          "token ID constrained but mismatched",
+			filter:        TokenFilter{{ContractAddress: tokenAddr, TokenID: big.NewInt(7)}},
+			contractAddr:  tokenAddr,
+			tokenID:       big.NewInt(8),
+			expectMatches: false,
+		},
+		{
+			name: "matches the second identity in a multi-entry filter",
+			filter: TokenFilter{
+				{ContractAddress: otherAddr},
+				{ContractAddress: tokenAddr, TokenID: big.NewInt(7)},
+			},
+			contractAddr:  tokenAddr,
+			tokenID:       big.NewInt(7),
+			expectMatches: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(tt.contractAddr, tt.chainID, tt.tokenID); got != tt.expectMatches {
+				t.Errorf("matches() = %v, want %v", got, tt.expectMatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	clientTypes "github.com/ava-labs/avalanche-rosetta/client"
+)
+
+func TestTraceOpsSkipsZeroValueCalls(t *testing.T) {
+	trace := []*clientTypes.FlatCall{
+		benchFlatCall(1, 0, OpCall),
+	}
+	ops := traceOps(trace, 0)
+	if len(ops) != 0 {
+		t.Fatalf("got %d ops, want 0 for a zero-value CALL", len(ops))
+	}
+}
+
+func TestTraceOpsEmitsFromToPairForValueTransfer(t *testing.T) {
+	trace := []*clientTypes.FlatCall{
+		benchFlatCall(1, 100, OpCall),
+	}
+	ops := traceOps(trace, 5)
+	if len(ops) != 2 {
+		t.Fatalf("got %d ops, want 2", len(ops))
+	}
+	if ops[0].OperationIdentifier.Index != 5 || ops[0].Amount.Value != "-100" {
+		t.Errorf("ops[0] = %+v, want index 5 and amount -100", ops[0])
+	}
+	if ops[1].OperationIdentifier.Index != 6 || ops[1].Amount.Value != "100" {
+		t.Errorf("ops[1] = %+v, want index 6 and amount 100", ops[1])
+	}
+	if len(ops[1].RelatedOperations) != 1 || ops[1].RelatedOperations[0].Index != 5 {
+		t.Errorf("ops[1].RelatedOperations = %+v, want a single link to index 5", ops[1].RelatedOperations)
+	}
+}
+
+func TestTraceOpsSkipsReceiveOpForSelfDestructToSelf(t *testing.T) {
+	call := benchFlatCall(1, 0, OpSelfDestruct)
+	call.To = call.From
+
+	ops := traceOps([]*clientTypes.FlatCall{call}, 0)
+	// SELFDESTRUCT is not a call type, so its sending op is kept even at zero
+	// value; only the receiving op is skipped, since sending to self is a
+	// balance no-op.
+	if len(ops) != 1 {
+		t.Fatalf("got %d ops, want 1 (only the sending op) for a SELFDESTRUCT to self", len(ops))
+	}
+	if ops[0].Account.Address != call.From.String() || ops[0].Amount != nil {
+		t.Errorf("ops[0] = %+v, want the sending account with no amount", ops[0])
+	}
+}
+
+func TestTraceOpsZeroesOutDestroyedAccountBalance(t *testing.T) {
+	destroyed := benchFlatCall(1, 0, OpSelfDestruct)
+	credit := benchFlatCall(2, 50, OpCall)
+	credit.To = destroyed.From
+
+	ops := traceOps([]*clientTypes.FlatCall{destroyed, credit}, 0)
+
+	var destructOp *types.Operation
+	for _, op := range ops {
+		if op.Type == OpDestruct {
+			destructOp = op
+		}
+	}
+	if destructOp == nil {
+		t.Fatalf("got %+v, want a %s op zeroing out the post-SELFDESTRUCT credit", ops, OpDestruct)
+	}
+	if destructOp.Amount.Value != "-50" {
+		t.Errorf("destruct amount = %s, want -50 (netting the credit back out)", destructOp.Amount.Value)
+	}
+}
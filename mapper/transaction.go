@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
@@ -15,7 +18,9 @@ import (
 	ethtypes "github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/plugin/evm"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 
 	clientTypes "github.com/ava-labs/avalanche-rosetta/client"
 	"github.com/ava-labs/avalanche-rosetta/constants"
@@ -26,6 +31,28 @@ const (
 	topicsInErc20Transfer  = 3
 
 	transferMethodHash = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+	transferSingleMethodHash = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"
+	transferBatchMethodHash  = "0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"
+
+	// OpBaseFeeBurn represents the portion of an EIP-1559 transaction fee
+	// that is burned (sent to the zero address) rather than credited to
+	// the block's coinbase.
+	OpBaseFeeBurn = "FEE_BURN"
+
+	// OpBlobFeeBurn represents the EIP-4844 blob-gas fee, which is burned
+	// in its entirety rather than credited to the block's coinbase.
+	OpBlobFeeBurn = "BLOB_FEE_BURN"
+
+	OpErc1155Mint            = "ERC1155_MINT"
+	OpErc1155Burn            = "ERC1155_BURN"
+	OpErc1155TransferSender  = "ERC1155_TRANSFER_SENDER"
+	OpErc1155TransferReceive = "ERC1155_TRANSFER_RECEIVE"
+)
+
+var (
+	uint256Type, _      = abi.NewType("uint256", "", nil)
+	uint256ArrayType, _ = abi.NewType("uint256[]", "", nil)
 )
 
 var (
@@ -33,6 +60,47 @@ var (
 	zeroAddress = common.Address{}
 )
 
+// TokenIdentity uniquely identifies an ERC-20 or ERC-721 token for the
+// purposes of filtering which log-derived operations are emitted. TokenID
+// is only meaningful for ERC-721 (and is nil for ERC-20 identities).
+type TokenIdentity struct {
+	ContractAddress common.Address `json:"contract_address"`
+	ChainID         *big.Int       `json:"chain_id,omitempty"`
+	TokenID         *big.Int       `json:"token_id,omitempty"`
+}
+
+// TokenFilter is a set of TokenIdentity values that Transaction uses to
+// restrict which ERC-20/ERC-721 transfer logs are turned into operations.
+// An empty/nil TokenFilter matches everything.
+//
+// NOTE: nothing in this package builds a TokenFilter from a caller's
+// request — the service layer that would do that (e.g. a block/transaction
+// request's query parameters) isn't part of this snapshot of the
+// repository, so that wiring still needs to be added where Transaction is
+// actually called.
+type TokenFilter []TokenIdentity
+
+func (f TokenFilter) matches(contractAddress common.Address, chainID *big.Int, tokenID *big.Int) bool {
+	if len(f) == 0 {
+		return true
+	}
+
+	for _, identity := range f {
+		if identity.ContractAddress != contractAddress {
+			continue
+		}
+		if identity.ChainID != nil && (chainID == nil || identity.ChainID.Cmp(chainID) != 0) {
+			continue
+		}
+		if identity.TokenID != nil && (tokenID == nil || identity.TokenID.Cmp(tokenID) != 0) {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
+
 func Transaction(
 	header *ethtypes.Header,
 	tx *ethtypes.Transaction,
@@ -44,47 +112,175 @@ func Transaction(
 	isAnalyticsMode bool,
 	standardModeWhiteList []string,
 	includeUnknownTokens bool,
+	tokenFilter TokenFilter,
+	decoders *DecoderRegistry,
 ) (*types.Transaction, error) {
 	ops := []*types.Operation{}
 	sender := msg.From
 	feeReceiver := &header.Coinbase
 
-	txFee := new(big.Int).SetUint64(receipt.GasUsed)
-	txFee = txFee.Mul(txFee, msg.GasPrice)
+	gasUsed := new(big.Int).SetUint64(receipt.GasUsed)
+	effectiveGasPrice := msg.GasPrice
+	txFee := new(big.Int).Mul(gasUsed, effectiveGasPrice)
 
-	feeOps := []*types.Operation{
-		{
-			OperationIdentifier: &types.OperationIdentifier{
-				Index: 0,
+	feeMetadata := map[string]interface{}{}
+
+	var feeOps []*types.Operation
+	// Fee burning is a property of the block (post-London), not the tx
+	// type: for legacy/access-list txs, GasFeeCap == GasTipCap == GasPrice,
+	// so the same tip/burn split degenerates correctly without a type check.
+	if header.BaseFee != nil {
+		baseFee := header.BaseFee
+		tipAmount, burnAmount := tipBurnSplit(gasUsed, effectiveGasPrice, baseFee)
+
+		feeOps = []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: 0,
+				},
+				Type:    OpFee,
+				Status:  types.String(StatusSuccess),
+				Account: Account(&sender),
+				Amount:  AvaxAmount(new(big.Int).Neg(txFee)),
 			},
-			Type:    OpFee,
-			Status:  types.String(StatusSuccess),
-			Account: Account(&sender),
-			Amount:  AvaxAmount(new(big.Int).Neg(txFee)),
-		},
-		{
-			OperationIdentifier: &types.OperationIdentifier{
-				Index: 1,
+			{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: 1,
+				},
+				RelatedOperations: []*types.OperationIdentifier{
+					{
+						Index: 0,
+					},
+				},
+				Type:    OpFee,
+				Status:  types.String(StatusSuccess),
+				Account: Account(feeReceiver),
+				Amount:  AvaxAmount(tipAmount),
 			},
-			RelatedOperations: []*types.OperationIdentifier{
-				{
+			{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: 2,
+				},
+				RelatedOperations: []*types.OperationIdentifier{
+					{
+						Index: 0,
+					},
+				},
+				Type:    OpBaseFeeBurn,
+				Status:  types.String(StatusSuccess),
+				Account: Account(&zeroAddress),
+				Amount:  AvaxAmount(burnAmount),
+			},
+		}
+
+		feeMetadata["base_fee"] = baseFee.String()
+		feeMetadata["effective_gas_price"] = effectiveGasPrice.String()
+		feeMetadata["max_fee_per_gas"] = tx.GasFeeCap().String()
+		feeMetadata["max_priority_fee_per_gas"] = tx.GasTipCap().String()
+	} else {
+		feeOps = []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{
 					Index: 0,
 				},
+				Type:    OpFee,
+				Status:  types.String(StatusSuccess),
+				Account: Account(&sender),
+				Amount:  AvaxAmount(new(big.Int).Neg(txFee)),
 			},
-			Type:    OpFee,
-			Status:  types.String(StatusSuccess),
-			Account: Account(feeReceiver),
-			Amount:  AvaxAmount(txFee),
-		},
+			{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: 1,
+				},
+				RelatedOperations: []*types.OperationIdentifier{
+					{
+						Index: 0,
+					},
+				},
+				Type:    OpFee,
+				Status:  types.String(StatusSuccess),
+				Account: Account(feeReceiver),
+				Amount:  AvaxAmount(txFee),
+			},
+		}
+	}
+
+	if tx.Type() == ethtypes.AccessListTxType || tx.Type() == ethtypes.DynamicFeeTxType {
+		feeMetadata["access_list"] = tx.AccessList()
+	}
+
+	if tx.Type() == ethtypes.BlobTxType {
+		blobGasFeeCap := tx.BlobGasFeeCap()
+		blobGasPrice := blobGasFeeCap
+		if header.ExcessBlobGas != nil {
+			blobGasPrice = eip4844.CalcBlobFee(*header.ExcessBlobGas)
+		}
+
+		blobFee := blobFeeAmount(receipt.BlobGasUsed, blobGasPrice)
+
+		feeMetadata["blob_versioned_hashes"] = tx.BlobHashes()
+		feeMetadata["max_fee_per_blob_gas"] = blobGasFeeCap.String()
+		feeMetadata["blob_gas_used"] = receipt.BlobGasUsed
+
+		blobDebitIndex := int64(len(feeOps))
+		feeOps = append(feeOps,
+			&types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: blobDebitIndex,
+				},
+				RelatedOperations: []*types.OperationIdentifier{
+					{
+						Index: 0,
+					},
+				},
+				Type:    OpBlobFeeBurn,
+				Status:  types.String(StatusSuccess),
+				Account: Account(&sender),
+				Amount:  AvaxAmount(new(big.Int).Neg(blobFee)),
+			},
+			&types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: blobDebitIndex + 1,
+				},
+				RelatedOperations: []*types.OperationIdentifier{
+					{
+						Index: blobDebitIndex,
+					},
+				},
+				Type:    OpBlobFeeBurn,
+				Status:  types.String(StatusSuccess),
+				Account: Account(&zeroAddress),
+				Amount:  AvaxAmount(blobFee),
+			},
+		)
 	}
 
 	ops = append(ops, feeOps...)
 
 	traceOps := traceOps(flattenedTrace, len(feeOps))
 	ops = append(ops, traceOps...)
+
+	involvedTokens := map[common.Address]TokenIdentity{}
+	chainID := tx.ChainId()
+	decoderCtx := &LogDecoderContext{Header: header, Tx: tx, Client: client}
 	for _, log := range receipt.Logs {
-		// Only check transfer logs
-		if len(log.Topics) == 0 || log.Topics[0].String() != transferMethodHash {
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		if decoders != nil {
+			decodedOps, handled, err := decoders.decode(log, decoderCtx, int64(len(ops)), isAnalyticsMode, standardModeWhiteList)
+			if err != nil {
+				return nil, err
+			}
+			if handled {
+				ops = append(ops, decodedOps...)
+				continue
+			}
+		}
+
+		topic0 := log.Topics[0].String()
+		if topic0 != transferMethodHash && topic0 != transferSingleMethodHash && topic0 != transferBatchMethodHash {
 			continue
 		}
 
@@ -93,6 +289,47 @@ func Transaction(
 			continue
 		}
 
+		if topic0 == transferSingleMethodHash || topic0 == transferBatchMethodHash {
+			name := clientTypes.UnknownERC1155Symbol
+			if erc1155Client, ok := client.(erc1155ContractInfoClient); ok {
+				var err error
+				name, _, err = erc1155Client.GetContractInfo1155(log.Address)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if name == clientTypes.UnknownERC1155Symbol && !includeUnknownTokens {
+				continue
+			}
+
+			operator := common.BytesToAddress(log.Topics[1].Bytes())
+			from := common.BytesToAddress(log.Topics[2].Bytes())
+			to := common.BytesToAddress(log.Topics[3].Bytes())
+
+			if topic0 == transferSingleMethodHash {
+				tokenID, amount, err := decodeErc1155Single(log.Data)
+				if err != nil {
+					return nil, err
+				}
+
+				erc1155Ops := erc1155Ops(log, operator, from, to, tokenID, amount, int64(len(ops)))
+				ops = append(ops, erc1155Ops...)
+				continue
+			}
+
+			ids, amounts, err := decodeErc1155Batch(log.Data)
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range ids {
+				batchOps := erc1155Ops(log, operator, from, to, ids[i], amounts[i], int64(len(ops)))
+				ops = append(ops, batchOps...)
+			}
+			continue
+		}
+
 		switch len(log.Topics) {
 		case topicsInErc721Transfer:
 			symbol, _, err := client.GetContractInfo(log.Address, false)
@@ -104,6 +341,12 @@ func Transaction(
 				continue
 			}
 
+			tokenID := log.Topics[3].Big()
+			if !tokenFilter.matches(log.Address, chainID, tokenID) {
+				continue
+			}
+			involvedTokens[log.Address] = TokenIdentity{ContractAddress: log.Address, ChainID: chainID, TokenID: tokenID}
+
 			erc721Ops := erc721Ops(log, int64(len(ops)))
 			ops = append(ops, erc721Ops...)
 		case topicsInErc20Transfer:
@@ -116,33 +359,169 @@ func Transaction(
 				continue
 			}
 
+			if !tokenFilter.matches(log.Address, chainID, nil) {
+				continue
+			}
+			involvedTokens[log.Address] = TokenIdentity{ContractAddress: log.Address, ChainID: chainID}
+
 			erc20Ops := erc20Ops(log, ToCurrency(symbol, decimals, log.Address), int64(len(ops)))
 			ops = append(ops, erc20Ops...)
 		default:
 		}
 	}
 
+	metadata := map[string]interface{}{
+		"gas":       tx.Gas(),
+		"gas_price": tx.GasPrice().String(),
+		"receipt":   receipt,
+		"trace":     trace,
+		"type":      tx.Type(),
+	}
+	for k, v := range feeMetadata {
+		metadata[k] = v
+	}
+	// Populate involved_tokens whenever any token actually passed the
+	// filter, regardless of whether a filter was supplied (an empty filter
+	// matches everything, so every involved token ends up here too) —
+	// mirrors crossChainTransaction's native-asset population below.
+	if len(involvedTokens) > 0 {
+		addresses := make([]common.Address, 0, len(involvedTokens))
+		for address := range involvedTokens {
+			addresses = append(addresses, address)
+		}
+		sort.Slice(addresses, func(i, j int) bool {
+			return addresses[i].Hex() < addresses[j].Hex()
+		})
+		matched := make([]TokenIdentity, 0, len(involvedTokens))
+		for _, address := range addresses {
+			matched = append(matched, involvedTokens[address])
+		}
+		metadata["involved_tokens"] = matched
+	}
+
 	return &types.Transaction{
 		TransactionIdentifier: &types.TransactionIdentifier{
 			Hash: tx.Hash().String(),
 		},
 		Operations: ops,
-		Metadata: map[string]interface{}{
-			"gas":       tx.Gas(),
-			"gas_price": tx.GasPrice().String(),
-			"receipt":   receipt,
-			"trace":     trace,
-			"type":      tx.Type(),
-		},
+		Metadata:   metadata,
 	}, nil
 }
 
+// Options controls opt-in behavior for mapping a block's transactions.
+//
+// NOTE: nothing in this package decides Concurrent from request/deployment
+// config - the service layer that would construct an Options{Concurrent:
+// true} isn't part of this snapshot of the repository, so that wiring still
+// needs to be added where BlockTransactions is actually called.
+type Options struct {
+	// Concurrent maps each transaction's receipt/trace/log triple on a
+	// worker pool bounded by GOMAXPROCS instead of serially. Results are
+	// always returned in input order.
+	Concurrent bool
+}
+
+// BlockTransactionInput bundles everything Transaction needs for a single
+// transaction so BlockTransactions can fan the block's transactions out
+// across a worker pool.
+type BlockTransactionInput struct {
+	Tx             *ethtypes.Transaction
+	Msg            *core.Message
+	Receipt        *ethtypes.Receipt
+	Trace          *clientTypes.Call
+	FlattenedTrace []*clientTypes.FlatCall
+}
+
+// BlockTransactions maps every transaction in a block to its Rosetta
+// representation, optionally in parallel via opts.Concurrent. The returned
+// slice always preserves the order of inputs.
+func BlockTransactions(
+	header *ethtypes.Header,
+	inputs []*BlockTransactionInput,
+	client clientTypes.Client,
+	isAnalyticsMode bool,
+	standardModeWhiteList []string,
+	includeUnknownTokens bool,
+	tokenFilter TokenFilter,
+	decoders *DecoderRegistry,
+	opts *Options,
+) ([]*types.Transaction, error) {
+	results := make([]*types.Transaction, len(inputs))
+
+	mapOne := func(i int) error {
+		in := inputs[i]
+		tx, err := Transaction(
+			header,
+			in.Tx,
+			in.Msg,
+			in.Receipt,
+			in.Trace,
+			in.FlattenedTrace,
+			client,
+			isAnalyticsMode,
+			standardModeWhiteList,
+			includeUnknownTokens,
+			tokenFilter,
+			decoders,
+		)
+		if err != nil {
+			return err
+		}
+		results[i] = tx
+		return nil
+	}
+
+	if opts == nil || !opts.Concurrent || len(inputs) <= 1 {
+		for i := range inputs {
+			if err := mapOne(i); err != nil {
+				return nil, err
+			}
+		}
+		return results, nil
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+
+	jobs := make(chan int)
+	errs := make(chan error, len(inputs))
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := mapOne(i); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	for i := range inputs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func crossChainTransaction(
 	networkIdentifier *types.NetworkIdentifier,
 	chainIDToAliasMapping map[ids.ID]constants.ChainIDAlias,
 	rawIdx int,
 	avaxAssetID string,
 	tx *evm.Tx,
+	tokenFilter TokenFilter,
 ) ([]*types.Operation, map[string]interface{}, error) {
 	var (
 		ops          = []*types.Operation{}
@@ -272,6 +651,13 @@ func crossChainTransaction(
 	txFeeAtomicAvax := new(big.Int).Sub(totalInputAmount, totalOutputAmount)
 	metadata[MetadataTxFee] = AtomicAvaxAmount(txFeeAtomicAvax)
 
+	// Atomic transactions only ever move the chain's native asset, so a
+	// caller filtering for it registers TokenIdentity{ContractAddress:
+	// zeroAddress}; an empty filter matches everything, same as Transaction.
+	if tokenFilter.matches(zeroAddress, nil, nil) {
+		metadata["involved_tokens"] = []TokenIdentity{{ContractAddress: zeroAddress}}
+	}
+
 	return ops, metadata, nil
 }
 
@@ -329,6 +715,7 @@ func CrossChainTransactions(
 	avaxAssetID string,
 	block *ethtypes.Block,
 	ap5Activation uint64,
+	tokenFilter TokenFilter,
 ) ([]*types.Transaction, error) {
 	transactions := []*types.Transaction{}
 
@@ -343,7 +730,7 @@ func CrossChainTransactions(
 	}
 
 	for _, tx := range atomicTxs {
-		txOps, metadata, err := crossChainTransaction(networkIdentifier, chainIDToAliasMapping, 0, avaxAssetID, tx)
+		txOps, metadata, err := crossChainTransaction(networkIdentifier, chainIDToAliasMapping, 0, avaxAssetID, tx, tokenFilter)
 		if err != nil {
 			return nil, err
 		}
@@ -380,66 +767,92 @@ func MempoolTransactionsIDs(accountMap clientTypes.TxAccountMap) []*types.Transa
 	return result
 }
 
+// negScratchPool holds scratch big.Ints used to negate a call's value long
+// enough to render it to a string. It exists to cut down on allocations in
+// traceOps for blocks with many thousands of internal calls.
+var negScratchPool = sync.Pool{
+	New: func() interface{} { return new(big.Int) },
+}
+
+func negAmountString(v *big.Int) string {
+	scratch := negScratchPool.Get().(*big.Int)
+	scratch.Neg(v)
+	s := scratch.String()
+	negScratchPool.Put(scratch)
+	return s
+}
+
+// tipBurnSplit divides a transaction's gas*price fee into the portion
+// credited to the block's coinbase (the tip, i.e. effective price above base
+// fee) and the portion burned at the base fee, per EIP-1559. Pulled out of
+// Transaction so the split can be unit tested without a constructed
+// *ethtypes.Transaction.
+func tipBurnSplit(gasUsed, effectiveGasPrice, baseFee *big.Int) (tipAmount, burnAmount *big.Int) {
+	tipPerGas := new(big.Int).Sub(effectiveGasPrice, baseFee)
+	if tipPerGas.Sign() < 0 {
+		tipPerGas = big.NewInt(0)
+	}
+	return new(big.Int).Mul(tipPerGas, gasUsed), new(big.Int).Mul(baseFee, gasUsed)
+}
+
+// blobFeeAmount computes the total EIP-4844 blob fee burned by a
+// transaction, entirely separate from tipBurnSplit's execution-gas burn.
+// Pulled out of Transaction so it can be unit tested directly.
+func blobFeeAmount(blobGasUsed uint64, blobGasPrice *big.Int) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(blobGasUsed), blobGasPrice)
+}
+
+// traceCallMeta carries the per-call decisions made during traceOps' first
+// pass so the second pass doesn't need to re-derive them.
+type traceCallMeta struct {
+	shouldAdd bool
+	zeroValue bool
+	skipToOp  bool
+	opStatus  string
+	errMsg    string
+}
+
+// traceOps converts a flattened call trace into operations in two passes.
+// The first pass walks the trace once to decide which calls contribute
+// operations, resolve the final destroyed-account balance deltas, and size
+// the result slice; the second pass fills in the operations themselves.
+// Splitting the work this way avoids reallocating ops as it grows and lets
+// the (to-be-discarded) negation scratch space come from negScratchPool
+// instead of a fresh allocation per call.
 func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation {
-	ops := []*types.Operation{}
 	if len(trace) == 0 {
-		return ops
+		return []*types.Operation{}
 	}
 
+	metas := make([]traceCallMeta, len(trace))
 	destroyedAccounts := map[string]*big.Int{}
-	for _, call := range trace {
-		// Handle partial transaction success
-		metadata := map[string]interface{}{}
-		opStatus := StatusSuccess
+	numOps := 0
+
+	for i, call := range trace {
+		meta := traceCallMeta{opStatus: StatusSuccess}
 		if call.Revert {
-			opStatus = StatusFailure
-			metadata["error"] = call.Error
+			meta.opStatus = StatusFailure
+			meta.errMsg = call.Error
 		}
 
-		var zeroValue bool
-		if call.Value.Sign() == 0 {
-			zeroValue = true
-		}
+		meta.zeroValue = call.Value.Sign() == 0
 
 		// Skip all 0 value CallType operations (TODO: make optional to include)
 		//
 		// We can't continue here because we may need to adjust our destroyed
 		// accounts map if a CallTYpe operation resurrects an account.
-		shouldAdd := true
-		if zeroValue && CallType(call.Type) {
-			shouldAdd = false
-		}
+		meta.shouldAdd = !(meta.zeroValue && CallType(call.Type))
 
-		// Checksum addresses
 		from := call.From.String()
 		to := call.To.String()
 
-		if shouldAdd {
-			fromOp := &types.Operation{
-				OperationIdentifier: &types.OperationIdentifier{
-					Index: int64(len(ops) + startIndex),
-				},
-				Type:   call.Type,
-				Status: types.String(opStatus),
-				Account: &types.AccountIdentifier{
-					Address: from,
-				},
-				Amount: &types.Amount{
-					Value:    new(big.Int).Neg(call.Value).String(),
-					Currency: AvaxCurrency,
-				},
-				Metadata: metadata,
-			}
-			if zeroValue {
-				fromOp.Amount = nil
-			} else {
-				_, destroyed := destroyedAccounts[from]
-				if destroyed && opStatus == StatusSuccess {
+		if meta.shouldAdd {
+			numOps++
+			if !meta.zeroValue {
+				if _, destroyed := destroyedAccounts[from]; destroyed && meta.opStatus == StatusSuccess {
 					destroyedAccounts[from] = new(big.Int).Sub(destroyedAccounts[from], call.Value)
 				}
 			}
-
-			ops = append(ops, fromOp)
 		}
 
 		// Add to destroyed accounts if SELFDESTRUCT
@@ -452,6 +865,8 @@ func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation
 			// after the balance is increased on the destination
 			// so this is a no-op.
 			if from == to {
+				meta.skipToOp = true
+				metas[i] = meta
 				continue
 			}
 		}
@@ -459,7 +874,9 @@ func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation
 		// Skip empty to addresses (this may not
 		// actually occur but leaving it as a
 		// sanity check)
-		if len(call.To.String()) == 0 {
+		if len(to) == 0 {
+			meta.skipToOp = true
+			metas[i] = meta
 			continue
 		}
 
@@ -469,38 +886,89 @@ func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation
 			delete(destroyedAccounts, to)
 		}
 
-		if shouldAdd {
-			lastOpIndex := ops[len(ops)-1].OperationIdentifier.Index
+		if meta.shouldAdd {
+			numOps++
+			if !meta.zeroValue {
+				if _, destroyed := destroyedAccounts[to]; destroyed && meta.opStatus == StatusSuccess {
+					destroyedAccounts[to] = new(big.Int).Add(destroyedAccounts[to], call.Value)
+				}
+			}
+		}
+
+		metas[i] = meta
+	}
+
+	for _, val := range destroyedAccounts {
+		if val.Sign() != 0 {
+			numOps++
+		}
+	}
+
+	ops := make([]*types.Operation, 0, numOps)
+	opIndex := int64(startIndex)
+	for i, call := range trace {
+		meta := metas[i]
+		metadata := map[string]interface{}{}
+		if meta.opStatus == StatusFailure {
+			metadata["error"] = meta.errMsg
+		}
+
+		from := call.From.String()
+		to := call.To.String()
+
+		if meta.shouldAdd {
+			fromOp := &types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{
+					Index: opIndex,
+				},
+				Type:   call.Type,
+				Status: types.String(meta.opStatus),
+				Account: &types.AccountIdentifier{
+					Address: from,
+				},
+				Metadata: metadata,
+			}
+			if !meta.zeroValue {
+				fromOp.Amount = &types.Amount{
+					Value:    negAmountString(call.Value),
+					Currency: AvaxCurrency,
+				}
+			}
+
+			ops = append(ops, fromOp)
+			opIndex++
+		}
+
+		if meta.skipToOp {
+			continue
+		}
+
+		if meta.shouldAdd {
 			toOp := &types.Operation{
 				OperationIdentifier: &types.OperationIdentifier{
-					Index: lastOpIndex + 1,
+					Index: opIndex,
 				},
 				RelatedOperations: []*types.OperationIdentifier{
 					{
-						Index: lastOpIndex,
+						Index: opIndex - 1,
 					},
 				},
 				Type:   call.Type,
-				Status: types.String(opStatus),
+				Status: types.String(meta.opStatus),
 				Account: &types.AccountIdentifier{
 					Address: to,
 				},
-				Amount: &types.Amount{
-					Value:    call.Value.String(),
-					Currency: AvaxCurrency,
-				},
 				Metadata: metadata,
 			}
-			if zeroValue {
-				toOp.Amount = nil
-			} else {
-				_, destroyed := destroyedAccounts[to]
-				if destroyed && opStatus == StatusSuccess {
-					destroyedAccounts[to] = new(big.Int).Add(destroyedAccounts[to], call.Value)
+			if !meta.zeroValue {
+				toOp.Amount = &types.Amount{
+					Value:    call.Value.String(),
+					Currency: AvaxCurrency,
 				}
 			}
 
 			ops = append(ops, toOp)
+			opIndex++
 		}
 	}
 
@@ -517,7 +985,7 @@ func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation
 
 		ops = append(ops, &types.Operation{
 			OperationIdentifier: &types.OperationIdentifier{
-				Index: ops[len(ops)-1].OperationIdentifier.Index + 1,
+				Index: opIndex,
 			},
 			Type:   OpDestruct,
 			Status: types.String(StatusSuccess),
@@ -525,10 +993,11 @@ func traceOps(trace []*clientTypes.FlatCall, startIndex int) []*types.Operation
 				Address: acct,
 			},
 			Amount: &types.Amount{
-				Value:    new(big.Int).Neg(val).String(),
+				Value:    negAmountString(val),
 				Currency: AvaxCurrency,
 			},
 		})
+		opIndex++
 	}
 
 	return ops
@@ -649,3 +1118,106 @@ func erc721Ops(transferLog *ethtypes.Log, opsLen int64) []*types.Operation {
 		},
 	}}
 }
+
+// erc1155ContractInfoClient is implemented by clients that can resolve an
+// ERC-1155 contract's name/uri. It is declared here, rather than assumed on
+// clientTypes.Client, because this snapshot of the repository does not
+// contain the client package's Client interface to extend; a client that
+// doesn't implement it is treated the same as one that resolved an unknown
+// symbol. Once the real Client interface is available, GetContractInfo1155
+// should move onto it directly (mirroring GetContractInfo) and this
+// assertion can be dropped.
+type erc1155ContractInfoClient interface {
+	GetContractInfo1155(address common.Address) (name, uri string, err error)
+}
+
+func decodeErc1155Single(data []byte) (tokenID *big.Int, amount *big.Int, err error) {
+	values, err := (abi.Arguments{{Type: uint256Type}, {Type: uint256Type}}).UnpackValues(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return values[0].(*big.Int), values[1].(*big.Int), nil
+}
+
+func decodeErc1155Batch(data []byte) (tokenIDs []*big.Int, amounts []*big.Int, err error) {
+	values, err := (abi.Arguments{{Type: uint256ArrayType}, {Type: uint256ArrayType}}).UnpackValues(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenIDs, amounts = values[0].([]*big.Int), values[1].([]*big.Int)
+	if len(tokenIDs) != len(amounts) {
+		return nil, nil, fmt.Errorf("erc1155 TransferBatch ids/values length mismatch: %d != %d", len(tokenIDs), len(amounts))
+	}
+
+	return tokenIDs, amounts, nil
+}
+
+func erc1155Ops(
+	transferLog *ethtypes.Log,
+	operator common.Address,
+	fromAddress common.Address,
+	toAddress common.Address,
+	tokenID *big.Int,
+	amount *big.Int,
+	opsLen int64,
+) []*types.Operation {
+	metadata := map[string]interface{}{
+		ContractAddressMetadata: transferLog.Address.String(),
+		"operator":              operator.String(),
+		"token_id":              tokenID.String(),
+		"amount":                amount.String(),
+	}
+
+	// Mint
+	if fromAddress == zeroAddress {
+		return []*types.Operation{{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: opsLen,
+			},
+			Status:   types.String(StatusSuccess),
+			Type:     OpErc1155Mint,
+			Account:  Account(&toAddress),
+			Metadata: metadata,
+		}}
+	}
+
+	// Burn
+	if toAddress == zeroAddress {
+		return []*types.Operation{{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: opsLen,
+			},
+			Status:   types.String(StatusSuccess),
+			Type:     OpErc1155Burn,
+			Account:  Account(&fromAddress),
+			Metadata: metadata,
+		}}
+	}
+
+	return []*types.Operation{{
+		// Send
+		OperationIdentifier: &types.OperationIdentifier{
+			Index: opsLen,
+		},
+		Status:   types.String(StatusSuccess),
+		Type:     OpErc1155TransferSender,
+		Account:  Account(&fromAddress),
+		Metadata: metadata,
+	}, {
+		// Receive
+		OperationIdentifier: &types.OperationIdentifier{
+			Index: opsLen + 1,
+		},
+		Status:   types.String(StatusSuccess),
+		Type:     OpErc1155TransferReceive,
+		Account:  Account(&toAddress),
+		Metadata: metadata,
+		RelatedOperations: []*types.OperationIdentifier{
+			{
+				Index: opsLen,
+			},
+		},
+	}}
+}
@@ -0,0 +1,63 @@
+package mapper
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestTipBurnSplit(t *testing.T) {
+	tests := []struct {
+		name              string
+		gasUsed           *big.Int
+		effectiveGasPrice *big.Int
+		baseFee           *big.Int
+		wantTip           *big.Int
+		wantBurn          *big.Int
+	}{
+		{
+			name:              "tip above base fee",
+			gasUsed:           big.NewInt(21000),
+			effectiveGasPrice: big.NewInt(30),
+			baseFee:           big.NewInt(20),
+			wantTip:           big.NewInt(21000 * 10),
+			wantBurn:          big.NewInt(21000 * 20),
+		},
+		{
+			name:              "effective price equal to base fee: no tip",
+			gasUsed:           big.NewInt(21000),
+			effectiveGasPrice: big.NewInt(20),
+			baseFee:           big.NewInt(20),
+			wantTip:           big.NewInt(0),
+			wantBurn:          big.NewInt(21000 * 20),
+		},
+		{
+			name: "legacy tx degenerates correctly: GasFeeCap == GasTipCap == GasPrice",
+			// effectiveGasPrice here stands in for a legacy tx's flat
+			// GasPrice, which is always >= baseFee for a valid block.
+			gasUsed:           big.NewInt(21000),
+			effectiveGasPrice: big.NewInt(50),
+			baseFee:           big.NewInt(50),
+			wantTip:           big.NewInt(0),
+			wantBurn:          big.NewInt(21000 * 50),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tip, burn := tipBurnSplit(tt.gasUsed, tt.effectiveGasPrice, tt.baseFee)
+			if tip.Cmp(tt.wantTip) != 0 {
+				t.Errorf("tip = %s, want %s", tip, tt.wantTip)
+			}
+			if burn.Cmp(tt.wantBurn) != 0 {
+				t.Errorf("burn = %s, want %s", burn, tt.wantBurn)
+			}
+			// The split must always account for the whole fee: tip + burn ==
+			// gasUsed * effectiveGasPrice.
+			total := new(big.Int).Add(tip, burn)
+			wantTotal := new(big.Int).Mul(tt.gasUsed, tt.effectiveGasPrice)
+			if total.Cmp(wantTotal) != 0 {
+				t.Errorf("tip + burn = %s, want %s (gasUsed * effectiveGasPrice)", total, wantTotal)
+			}
+		})
+	}
+}